@@ -0,0 +1,63 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/pkg/kube"
+)
+
+// uproxyContainerName is the container within a uproxy pod whose admin
+// endpoint serves the workload_metadata config dump.
+const uproxyContainerName = "istio-proxy"
+
+// uproxyConfigDumpCommand curls uproxy's admin endpoint for its current
+// workload_metadata config dump, the same endpoint WorkloadMetadataGenerator's
+// resources are read back from.
+const uproxyConfigDumpCommand = "curl -s http://localhost:15000/config_dump?resource=workload_metadata"
+
+// NewExecConfigDumpRetriever returns a ConfigDumpRetriever that reaches a
+// running uproxy by exec'ing into its pod via client and curling its admin
+// port, the same kube.ExecClient-backed mechanism istioctl's ztunnel
+// config-dump command uses to reach ztunnel's admin endpoint.
+func NewExecConfigDumpRetriever(client kube.ExecClient) ConfigDumpRetriever {
+	return func(podName, podNamespace string) ([]byte, error) {
+		stdout, stderr, err := client.PodExec(podName, podNamespace, uproxyContainerName, uproxyConfigDumpCommand)
+		if err != nil {
+			return nil, fmt.Errorf("failed to exec into %s/%s: %v, stderr: %s", podNamespace, podName, err, stderr.String())
+		}
+		return stdout.Bytes(), nil
+	}
+}
+
+// AddToExperimentalCommand registers the "uproxy" command group, with
+// WorkloadMetadataCmd backed by a real kube.ExecClient retriever, under
+// experimental (istioctl's "experimental" parent command), giving a working
+// "istioctl experimental uproxy workload-metadata" entry point. experimental
+// itself is assembled in istioctl's command root (outside this package),
+// which should call this alongside its other experimental registrations:
+//
+//	uproxy.AddToExperimentalCommand(experimentalCmd, kubeClient)
+func AddToExperimentalCommand(experimental *cobra.Command, client kube.ExecClient) {
+	uproxyCmd := &cobra.Command{
+		Use:   "uproxy",
+		Short: "Commands for interacting with the ambient uproxy",
+	}
+	uproxyCmd.AddCommand(WorkloadMetadataCmd(NewExecConfigDumpRetriever(client)))
+	experimental.AddCommand(uproxyCmd)
+}
@@ -0,0 +1,78 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxy
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type fakeExecClient struct {
+	podName, podNamespace, container, command string
+
+	stdout string
+	err    error
+}
+
+func (f *fakeExecClient) PodExec(podName, podNamespace, container, command string) (bytes.Buffer, bytes.Buffer, error) {
+	f.podName, f.podNamespace, f.container, f.command = podName, podNamespace, container, command
+	var stdout bytes.Buffer
+	stdout.WriteString(f.stdout)
+	return stdout, bytes.Buffer{}, f.err
+}
+
+func TestNewExecConfigDumpRetrieverReturnsStdout(t *testing.T) {
+	client := &fakeExecClient{stdout: `{"workloadMetadataResources":{}}`}
+	retriever := NewExecConfigDumpRetriever(client)
+
+	raw, err := retriever("uproxy-abc", "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(raw) != client.stdout {
+		t.Fatalf("expected retriever to return exec stdout, got %q", raw)
+	}
+	if client.podName != "uproxy-abc" || client.podNamespace != "default" {
+		t.Fatalf("expected exec to target uproxy-abc/default, got %s/%s", client.podNamespace, client.podName)
+	}
+	if client.container != uproxyContainerName {
+		t.Fatalf("expected exec to target container %q, got %q", uproxyContainerName, client.container)
+	}
+}
+
+func TestNewExecConfigDumpRetrieverPropagatesExecError(t *testing.T) {
+	client := &fakeExecClient{err: errors.New("pod not found")}
+	retriever := NewExecConfigDumpRetriever(client)
+
+	if _, err := retriever("uproxy-abc", "default"); err == nil {
+		t.Fatal("expected an error when exec fails")
+	}
+}
+
+func TestAddToExperimentalCommandRegistersWorkloadMetadata(t *testing.T) {
+	experimental := &cobra.Command{Use: "experimental"}
+	AddToExperimentalCommand(experimental, &fakeExecClient{})
+
+	cmd, _, err := experimental.Find([]string{"uproxy", "workload-metadata", "uproxy-abc"})
+	if err != nil {
+		t.Fatalf("expected workload-metadata to be registered under experimental, got: %v", err)
+	}
+	if cmd.Name() != "workload-metadata" {
+		t.Fatalf("expected to resolve the workload-metadata command, got %q", cmd.Name())
+	}
+}
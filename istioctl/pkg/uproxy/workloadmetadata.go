@@ -0,0 +1,89 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package uproxy holds the "istioctl experimental uproxy" subcommands.
+package uproxy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	uproxyconfigdump "istio.io/istio/istioctl/pkg/writer/uproxy/configdump"
+)
+
+// ConfigDumpRetriever fetches the raw workload_metadata TypedExtensionConfig
+// dump (the same one WorkloadMetadataGenerator produces) from podName in
+// podNamespace. Callers wire this to however they reach a running uproxy,
+// e.g. exec'ing into the pod and curling its admin endpoint, the same way
+// istioctl's ztunnel config-dump commands do.
+type ConfigDumpRetriever func(podName, podNamespace string) ([]byte, error)
+
+// WorkloadMetadataCmd returns "workload-metadata", registered under
+// "istioctl experimental uproxy". It fetches and renders the
+// WorkloadMetadataResource entries a running uproxy instance currently
+// holds, so operators can confirm which pods it sees, diagnose missing
+// canonical-service labels, and confirm delta pushes are landing.
+func WorkloadMetadataCmd(retriever ConfigDumpRetriever) *cobra.Command {
+	var (
+		uproxyNamespace string
+		namespace       string
+		node            string
+		podNameMatch    string
+		address         string
+		outputFormat    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "workload-metadata <uproxy-pod-name>",
+		Short: "Retrieves the workload metadata a uproxy instance is serving to its listener filter",
+		Long: `Retrieves the WorkloadMetadataResource entries a running uproxy instance currently holds for the
+pods on its node, and renders them as a table, JSON, or YAML. Use the --namespace, --node, --pod-name,
+and --address flags to narrow the result down to a single workload.`,
+		Example: `  # Show all workload metadata a uproxy instance knows about
+  istioctl experimental uproxy workload-metadata <uproxy-pod-name>
+
+  # Narrow down to a single workload namespace
+  istioctl experimental uproxy workload-metadata <uproxy-pod-name> --namespace default`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, err := retriever(args[0], uproxyNamespace)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve workload metadata config dump: %v", err)
+			}
+
+			writer := &uproxyconfigdump.ConfigWriter{Stdout: cmd.OutOrStdout()}
+			if err := writer.Prime(raw); err != nil {
+				return err
+			}
+
+			return writer.PrintWorkloadMetadataDump(uproxyconfigdump.Filter{
+				Namespace: namespace,
+				Node:      node,
+				PodName:   podNameMatch,
+				Address:   address,
+			}, outputFormat)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&uproxyNamespace, "uproxy-namespace", "", "istio-system",
+		"Namespace the uproxy pod named by <uproxy-pod-name> itself runs in")
+	cmd.PersistentFlags().StringVar(&namespace, "namespace", "", "Filter results to workloads in this namespace")
+	cmd.PersistentFlags().StringVar(&node, "node", "", "Filter by the Kubernetes node the workload runs on")
+	cmd.PersistentFlags().StringVar(&podNameMatch, "pod-name", "", "Filter by workload pod name")
+	cmd.PersistentFlags().StringVar(&address, "address", "", "Filter by workload IP address")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: one of table, json, yaml")
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxy
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWorkloadMetadataCmdPropagatesRetrieverError(t *testing.T) {
+	cmd := WorkloadMetadataCmd(func(podName, podNamespace string) ([]byte, error) {
+		return nil, errors.New("pod not found")
+	})
+	cmd.SetArgs([]string{"uproxy-abc"})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an error when the retriever fails")
+	}
+}
+
+func TestWorkloadMetadataCmdRendersTable(t *testing.T) {
+	// uproxy's admin config dump is protojson, not encoding/json: lowerCamelCase
+	// keys, enums as their string name.
+	cmd := WorkloadMetadataCmd(func(podName, podNamespace string) ([]byte, error) {
+		return []byte(`{"workloadMetadataResources":{"uid-1":{"instanceName":"reviews-v1-abcde","namespaceName":"default",` +
+			`"workloadType":"KUBERNETES_DEPLOYMENT"}}}`), nil
+	})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"uproxy-abc"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "reviews-v1-abcde") {
+		t.Fatalf("expected pod name in table output, got:\n%s", out.String())
+	}
+}
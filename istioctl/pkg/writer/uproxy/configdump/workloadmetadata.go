@@ -0,0 +1,165 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configdump renders the workload_metadata listener filter config a
+// uproxy instance is serving, in the same table/JSON/YAML styles as
+// istioctl's other Envoy and ztunnel config-dump writers.
+package configdump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+
+	"istio.io/istio/pkg/util/protomarshal"
+	wmpb "istio.io/istio/pkg/workloadmetadata/proto"
+)
+
+// ConfigWriter holds a primed workload metadata dump and renders it.
+type ConfigWriter struct {
+	Stdout io.Writer
+
+	dump *wmpb.WorkloadMetadataResources
+}
+
+// Prime unmarshals a uproxy's workload_metadata TypedExtensionConfig dump
+// (a protojson-encoded WorkloadMetadataResources, the same encoding uproxy's
+// admin config dump uses for every other resource) for later Print calls.
+func (c *ConfigWriter) Prime(raw []byte) error {
+	dump := &wmpb.WorkloadMetadataResources{}
+	if err := protomarshal.Unmarshal(raw, dump); err != nil {
+		return fmt.Errorf("error unmarshalling workload metadata dump: %v", err)
+	}
+	c.dump = dump
+	return nil
+}
+
+// Filter narrows which WorkloadMetadataResource entries get printed. An
+// empty field matches everything.
+type Filter struct {
+	Namespace string
+	Node      string
+	PodName   string
+	Address   string
+}
+
+func (f Filter) matches(r *wmpb.WorkloadMetadataResource) bool {
+	if f.Namespace != "" && f.Namespace != r.NamespaceName {
+		return false
+	}
+	if f.Node != "" && f.Node != r.NodeName {
+		return false
+	}
+	if f.PodName != "" && f.PodName != r.InstanceName {
+		return false
+	}
+	if f.Address != "" {
+		addressFound := false
+		for _, ip := range r.IpAddresses {
+			if ip == f.Address {
+				addressFound = true
+				break
+			}
+		}
+		if !addressFound {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ConfigWriter) filtered(f Filter) []*wmpb.WorkloadMetadataResource {
+	resources := c.dump.GetWorkloadMetadataResources()
+	out := make([]*wmpb.WorkloadMetadataResource, 0, len(resources))
+	for _, r := range resources {
+		if f.matches(r) {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].NamespaceName != out[j].NamespaceName {
+			return out[i].NamespaceName < out[j].NamespaceName
+		}
+		return out[i].InstanceName < out[j].InstanceName
+	})
+	return out
+}
+
+// PrintWorkloadMetadataDump renders the resources matching f in
+// outputFormat, one of "table" (default), "json", or "yaml".
+func (c *ConfigWriter) PrintWorkloadMetadataDump(f Filter, outputFormat string) error {
+	resources := c.filtered(f)
+
+	switch outputFormat {
+	case "json":
+		return c.printJSON(resources)
+	case "yaml":
+		return c.printYAML(resources)
+	default:
+		return c.printTable(resources)
+	}
+}
+
+func (c *ConfigWriter) printTable(resources []*wmpb.WorkloadMetadataResource) error {
+	w := tabwriter.NewWriter(c.Stdout, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD NAME\tNODE\tWORKLOAD\tTYPE\tADDRESSES")
+	for _, r := range resources {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			r.NamespaceName, r.InstanceName, r.NodeName, r.WorkloadName, r.WorkloadType, r.IpAddresses)
+	}
+	return w.Flush()
+}
+
+func (c *ConfigWriter) printJSON(resources []*wmpb.WorkloadMetadataResource) error {
+	b, err := marshalResources(resources)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.Stdout, string(b))
+	return err
+}
+
+func (c *ConfigWriter) printYAML(resources []*wmpb.WorkloadMetadataResource) error {
+	b, err := marshalResources(resources)
+	if err != nil {
+		return err
+	}
+	y, err := yaml.JSONToYAML(b)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(c.Stdout, string(y))
+	return err
+}
+
+// marshalResources renders resources as a JSON array using the same
+// protojson semantics Prime reads them back with (protomarshal.Marshal), so
+// e.g. WorkloadType comes out as "KUBERNETES_DEPLOYMENT" rather than the bare
+// enum int plain encoding/json would produce, matching the table output's use
+// of the enum's Stringer.
+func marshalResources(resources []*wmpb.WorkloadMetadataResource) ([]byte, error) {
+	raw := make([]json.RawMessage, len(resources))
+	for i, r := range resources {
+		b, err := protomarshal.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling workload metadata resource: %v", err)
+		}
+		raw[i] = b
+	}
+	return json.MarshalIndent(raw, "", "  ")
+}
@@ -0,0 +1,113 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdump
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"istio.io/istio/pkg/util/protomarshal"
+	wmpb "istio.io/istio/pkg/workloadmetadata/proto"
+)
+
+func primedWriter(t *testing.T) (*ConfigWriter, *bytes.Buffer) {
+	t.Helper()
+
+	dump := &wmpb.WorkloadMetadataResources{
+		ProxyId: "uproxy-1",
+		WorkloadMetadataResources: map[string]*wmpb.WorkloadMetadataResource{
+			"uid-reviews": {
+				Uid:           "uid-reviews",
+				InstanceName:  "reviews-v1-abcde",
+				NamespaceName: "default",
+				NodeName:      "node-a",
+				IpAddresses:   []string{"10.0.0.1"},
+				WorkloadName:  "reviews-v1",
+				WorkloadType:  wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT,
+			},
+			"uid-ratings": {
+				Uid:           "uid-ratings",
+				InstanceName:  "ratings-v1-fghij",
+				NamespaceName: "default",
+				NodeName:      "node-b",
+				IpAddresses:   []string{"10.0.0.2"},
+				WorkloadName:  "ratings-v1",
+				WorkloadType:  wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT,
+			},
+		},
+	}
+	// A live uproxy serializes its admin config dump with protojson, not
+	// encoding/json, so the fixture round-trips through the same encoding
+	// Prime is expected to parse.
+	raw, err := protomarshal.Marshal(dump)
+	if err != nil {
+		t.Fatalf("failed to marshal test dump: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	writer := &ConfigWriter{Stdout: out}
+	if err := writer.Prime(raw); err != nil {
+		t.Fatalf("Prime() returned error: %v", err)
+	}
+	return writer, out
+}
+
+func TestPrintWorkloadMetadataDumpTableFiltersByNode(t *testing.T) {
+	writer, out := primedWriter(t)
+
+	if err := writer.PrintWorkloadMetadataDump(Filter{Node: "node-a"}, "table"); err != nil {
+		t.Fatalf("PrintWorkloadMetadataDump() returned error: %v", err)
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "reviews-v1-abcde") {
+		t.Errorf("expected reviews pod in output, got:\n%s", got)
+	}
+	if strings.Contains(got, "ratings-v1-fghij") {
+		t.Errorf("did not expect ratings pod (different node) in output, got:\n%s", got)
+	}
+}
+
+func TestPrintWorkloadMetadataDumpJSON(t *testing.T) {
+	writer, out := primedWriter(t)
+
+	if err := writer.PrintWorkloadMetadataDump(Filter{Address: "10.0.0.2"}, "json"); err != nil {
+		t.Fatalf("PrintWorkloadMetadataDump() returned error: %v", err)
+	}
+
+	// The output is protojson-encoded (camelCase keys, enums as their string
+	// name), the same encoding Prime expects to read back, so decode each
+	// element with protomarshal rather than plain encoding/json.
+	var raw []json.RawMessage
+	if err := json.Unmarshal(out.Bytes(), &raw); err != nil {
+		t.Fatalf("failed to unmarshal json output: %v", err)
+	}
+	if len(raw) != 1 {
+		t.Fatalf("expected only the ratings pod to match the address filter, got %d entries", len(raw))
+	}
+	resource := &wmpb.WorkloadMetadataResource{}
+	if err := protomarshal.Unmarshal(raw[0], resource); err != nil {
+		t.Fatalf("failed to unmarshal workload metadata resource: %v", err)
+	}
+	if resource.InstanceName != "ratings-v1-fghij" {
+		t.Fatalf("expected the ratings pod, got %v", resource)
+	}
+	if !strings.Contains(out.String(), `"workloadType": "KUBERNETES_DEPLOYMENT"`) &&
+		!strings.Contains(out.String(), `"workloadType":"KUBERNETES_DEPLOYMENT"`) {
+		t.Fatalf("expected workloadType to render as its enum name, got:\n%s", out.String())
+	}
+}
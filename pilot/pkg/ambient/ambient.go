@@ -0,0 +1,192 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ambient maintains an in-memory view of the Kubernetes pods that
+// make up the ambient mesh data plane (sidecarless workloads), keyed in the
+// ways the uproxy/ztunnel xDS generators need to consume them.
+package ambient
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Cache is the read interface used by ambient xDS generators (e.g.
+// WorkloadMetadataGenerator) to look up the pods running on a given node and
+// to resolve the Kubernetes workload that controls a given pod.
+type Cache interface {
+	// SidecarlessWorkloads returns the current snapshot of ambient pods.
+	SidecarlessWorkloads() *Snapshot
+
+	// Owners returns the resolver used to walk a pod's controller chain up
+	// to the workload that owns it (Deployment, CronJob, StatefulSet, ...).
+	Owners() *OwnerResolver
+
+	// Networks returns the resolver used to determine which multi-network
+	// network a pod is reachable on.
+	Networks() *NetworkResolver
+
+	// OnNodesChanged registers a handler that's invoked after each
+	// UpdateSnapshot that changes any node's ambient pods, with the set of
+	// Kubernetes node names whose pods were added, removed or mutated in
+	// place; an UpdateSnapshot that changes nothing invokes no handler. Callers
+	// (e.g.
+	// uproxygen.RegisterPushTrigger, which turns this into a PushRequest) can
+	// target the push instead of fanning out to every connected uproxy.
+	OnNodesChanged(handler NodeEventHandler)
+
+	// UpdateSnapshot atomically replaces the current view of ambient pods.
+	// It's called by the ambient pod controller as informer events arrive.
+	UpdateSnapshot(snapshot *Snapshot)
+}
+
+// NodeEventHandler is invoked with the node names whose ambient pods changed
+// as of the most recent UpdateSnapshot call.
+type NodeEventHandler func(nodes map[string]struct{})
+
+// Snapshot is a point-in-time view of the ambient pods known to the cache,
+// indexed for the lookups the xDS generators need.
+type Snapshot struct {
+	Workloads WorkloadIndex
+}
+
+// WorkloadIndex indexes known ambient pods by the Kubernetes node they are
+// scheduled on.
+type WorkloadIndex struct {
+	ByNode map[string][]*PodInfo
+
+	// VersionsByNode mirrors ByNode but holds only each pod's UID mapped to
+	// its Kubernetes ResourceVersion, so delta pushes can diff "what changed
+	// on this node" without walking the full pod list. Keying on UID alone
+	// would miss a pod that's mutated in place (e.g. a relabel) without
+	// changing identity; ResourceVersion changes on every write to the pod,
+	// including those, so it doubles as a cheap per-pod content version.
+	VersionsByNode map[string]map[string]string
+}
+
+// NewWorkloadIndex builds a WorkloadIndex from a node->pods view, deriving
+// VersionsByNode so the two always stay in sync.
+func NewWorkloadIndex(byNode map[string][]*PodInfo) WorkloadIndex {
+	versions := make(map[string]map[string]string, len(byNode))
+	for node, pods := range byNode {
+		v := make(map[string]string, len(pods))
+		for _, pod := range pods {
+			v[string(pod.UID)] = pod.ResourceVersion
+		}
+		versions[node] = v
+	}
+	return WorkloadIndex{ByNode: byNode, VersionsByNode: versions}
+}
+
+// PodInfo wraps a Kubernetes pod for the xDS generators. It carries no
+// precomputed metadata: a pod's network and owning workload are resolved on
+// each lookup through the Cache's Networks and Owners resolvers.
+type PodInfo struct {
+	*v1.Pod
+}
+
+// cacheImpl is the default Cache implementation. Pods are pushed in by the
+// ambient pod controller (via UpdateSnapshot) as informer events arrive;
+// readers take an immutable snapshot under lock so index rebuilds never
+// race with a concurrent Generate call.
+type cacheImpl struct {
+	owners   *OwnerResolver
+	networks *NetworkResolver
+
+	mu       sync.RWMutex
+	snapshot *Snapshot
+	handlers []NodeEventHandler
+}
+
+// NewCache returns a Cache whose owner and network resolution are backed by
+// owners and networks respectively. Callers populate it over time via
+// UpdateSnapshot as the ambient pod controller observes changes.
+func NewCache(owners *OwnerResolver, networks *NetworkResolver) Cache {
+	return &cacheImpl{
+		owners:   owners,
+		networks: networks,
+		snapshot: &Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{})},
+	}
+}
+
+// UpdateSnapshot atomically replaces the current view of ambient pods and
+// notifies registered handlers of the nodes whose pods were added, removed or
+// mutated in place, if there are any.
+func (c *cacheImpl) UpdateSnapshot(snapshot *Snapshot) {
+	c.mu.Lock()
+	prev := c.snapshot
+	c.snapshot = snapshot
+	handlers := c.handlers
+	c.mu.Unlock()
+
+	changed := changedNodes(prev.Workloads.VersionsByNode, snapshot.Workloads.VersionsByNode)
+	if len(changed) == 0 {
+		return
+	}
+	for _, handler := range handlers {
+		handler(changed)
+	}
+}
+
+func (c *cacheImpl) OnNodesChanged(handler NodeEventHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers = append(c.handlers, handler)
+}
+
+// changedNodes returns the set of node names whose pod versions differ
+// between prev and next, covering nodes that gained or lost a pod as well as
+// nodes where a pod already present was mutated in place (same UID, new
+// ResourceVersion).
+func changedNodes(prev, next map[string]map[string]string) map[string]struct{} {
+	changed := make(map[string]struct{})
+	for node, versions := range next {
+		if !sameVersions(versions, prev[node]) {
+			changed[node] = struct{}{}
+		}
+	}
+	for node, versions := range prev {
+		if _, ok := next[node]; !ok && len(versions) > 0 {
+			changed[node] = struct{}{}
+		}
+	}
+	return changed
+}
+
+func sameVersions(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for uid, version := range a {
+		if bv, ok := b[uid]; !ok || bv != version {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *cacheImpl) SidecarlessWorkloads() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot
+}
+
+func (c *cacheImpl) Owners() *OwnerResolver {
+	return c.owners
+}
+
+func (c *cacheImpl) Networks() *NetworkResolver {
+	return c.networks
+}
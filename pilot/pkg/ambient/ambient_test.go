@@ -0,0 +1,110 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func podInfo(uid, node string) *PodInfo {
+	return podInfoVersion(uid, node, "1")
+}
+
+func podInfoVersion(uid, node, version string) *PodInfo {
+	return &PodInfo{Pod: &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), ResourceVersion: version},
+		Spec:       v1.PodSpec{NodeName: node},
+	}}
+}
+
+func TestCacheUpdateSnapshotNotifiesOnlyChangedNodes(t *testing.T) {
+	c := NewCache(NewOwnerResolver(nil, nil, nil, nil, nil), NewNetworkResolver(nil, nil, "")).(*cacheImpl)
+
+	var notified []map[string]struct{}
+	c.OnNodesChanged(func(nodes map[string]struct{}) { notified = append(notified, nodes) })
+
+	c.UpdateSnapshot(&Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{
+		"node-a": {podInfo("pod-1", "node-a")},
+		"node-b": {podInfo("pod-2", "node-b")},
+	})})
+	if len(notified) != 1 {
+		t.Fatalf("expected 1 notification for the initial snapshot, got %d", len(notified))
+	}
+	if _, ok := notified[0]["node-a"]; !ok {
+		t.Errorf("expected node-a in %v", notified[0])
+	}
+	if _, ok := notified[0]["node-b"]; !ok {
+		t.Errorf("expected node-b in %v", notified[0])
+	}
+
+	notified = nil
+	c.UpdateSnapshot(&Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{
+		"node-a": {podInfo("pod-1", "node-a"), podInfo("pod-3", "node-a")},
+		"node-b": {podInfo("pod-2", "node-b")},
+	})})
+	if len(notified) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notified))
+	}
+	if _, ok := notified[0]["node-a"]; !ok {
+		t.Errorf("expected only node-a to be reported changed, got %v", notified[0])
+	}
+	if _, ok := notified[0]["node-b"]; ok {
+		t.Errorf("node-b did not change and should not be reported, got %v", notified[0])
+	}
+
+	notified = nil
+	c.UpdateSnapshot(&Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{
+		"node-a": {podInfo("pod-1", "node-a"), podInfo("pod-3", "node-a")},
+		"node-b": {podInfo("pod-2", "node-b")},
+	})})
+	if len(notified) != 0 {
+		t.Fatalf("expected no notification for an unchanged snapshot, got %v", notified)
+	}
+}
+
+// TestCacheUpdateSnapshotNotifiesOnInPlaceMutation covers a pod that's
+// mutated without changing its UID, e.g. `kubectl label`: the UID set on its
+// node is identical to the previous snapshot, but the pod's ResourceVersion
+// moved, so the node must still be reported changed.
+func TestCacheUpdateSnapshotNotifiesOnInPlaceMutation(t *testing.T) {
+	c := NewCache(NewOwnerResolver(nil, nil, nil, nil, nil), NewNetworkResolver(nil, nil, "")).(*cacheImpl)
+
+	var notified []map[string]struct{}
+	c.OnNodesChanged(func(nodes map[string]struct{}) { notified = append(notified, nodes) })
+
+	c.UpdateSnapshot(&Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{
+		"node-a": {podInfoVersion("pod-1", "node-a", "1")},
+		"node-b": {podInfoVersion("pod-2", "node-b", "1")},
+	})})
+
+	notified = nil
+	c.UpdateSnapshot(&Snapshot{Workloads: NewWorkloadIndex(map[string][]*PodInfo{
+		"node-a": {podInfoVersion("pod-1", "node-a", "2")},
+		"node-b": {podInfoVersion("pod-2", "node-b", "1")},
+	})})
+	if len(notified) != 1 {
+		t.Fatalf("expected 1 notification for the relabeled pod, got %d", len(notified))
+	}
+	if _, ok := notified[0]["node-a"]; !ok {
+		t.Errorf("expected node-a to be reported changed after its pod's ResourceVersion moved, got %v", notified[0])
+	}
+	if _, ok := notified[0]["node-b"]; ok {
+		t.Errorf("node-b did not change and should not be reported, got %v", notified[0])
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"sync"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NetworkLabel marks the multi-network a namespace or node belongs to, for
+// cross-network ambient mesh deployments.
+const NetworkLabel = "topology.istio.io/network"
+
+type networkKey struct {
+	namespace string
+	node      string
+}
+
+// NetworkResolver resolves the network name a pod is reachable on: its
+// namespace's topology.istio.io/network label, falling back to its node's
+// label of the same name, falling back to the mesh-wide default network.
+// Results are cached by (namespace, node) and invalidated whenever the
+// relevant namespace or node's labels change.
+type NetworkResolver struct {
+	namespaces     cache.SharedIndexInformer
+	nodes          cache.SharedIndexInformer
+	defaultNetwork string
+
+	mu    sync.RWMutex
+	cache map[networkKey]string
+}
+
+// NewNetworkResolver builds a resolver backed by the given informers.
+// Either may be nil, in which case that label source is skipped. Pods whose
+// namespace and node are both unlabeled resolve to defaultNetwork, which is
+// typically the mesh's MeshNetworks-configured default network.
+func NewNetworkResolver(namespaces, nodes cache.SharedIndexInformer, defaultNetwork string) *NetworkResolver {
+	r := &NetworkResolver{
+		namespaces:     namespaces,
+		nodes:          nodes,
+		defaultNetwork: defaultNetwork,
+		cache:          make(map[networkKey]string),
+	}
+
+	r.invalidateOn(namespaces, func(key networkKey, name string) bool { return key.namespace == name })
+	r.invalidateOn(nodes, func(key networkKey, name string) bool { return key.node == name })
+
+	return r
+}
+
+func (r *NetworkResolver) invalidateOn(informer cache.SharedIndexInformer, affects func(key networkKey, name string) bool) {
+	if informer == nil {
+		return
+	}
+	handler := func(obj interface{}) {
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		objMeta, err := apimeta.Accessor(obj)
+		if err != nil {
+			return
+		}
+		name := objMeta.GetName()
+
+		r.mu.Lock()
+		for key := range r.cache {
+			if affects(key, name) {
+				delete(r.cache, key)
+			}
+		}
+		r.mu.Unlock()
+	}
+	// nolint: errcheck
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    handler,
+		UpdateFunc: func(_, obj interface{}) { handler(obj) },
+		DeleteFunc: handler,
+	})
+}
+
+// Resolve returns the network name for a pod running in namespace on node.
+func (r *NetworkResolver) Resolve(namespace, node string) string {
+	key := networkKey{namespace: namespace, node: node}
+
+	r.mu.RLock()
+	network, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok {
+		return network
+	}
+
+	network = r.resolve(namespace, node)
+
+	r.mu.Lock()
+	r.cache[key] = network
+	r.mu.Unlock()
+	return network
+}
+
+func (r *NetworkResolver) resolve(namespace, node string) string {
+	if network, ok := namespacedLabel(r.namespaces, namespace, NetworkLabel); ok {
+		return network
+	}
+	if network, ok := namespacedLabel(r.nodes, node, NetworkLabel); ok {
+		return network
+	}
+	return r.defaultNetwork
+}
+
+// namespacedLabel looks up a cluster-scoped object (a Namespace or a Node,
+// both keyed by name alone in their informer's indexer) and returns its
+// label value.
+func namespacedLabel(informer cache.SharedIndexInformer, name, label string) (string, bool) {
+	if informer == nil {
+		return "", false
+	}
+	obj, exists, err := informer.GetIndexer().GetByKey(name)
+	if err != nil || !exists {
+		return "", false
+	}
+	objMeta, err := apimeta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+	value, ok := objMeta.GetLabels()[label]
+	return value, ok
+}
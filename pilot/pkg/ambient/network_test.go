@@ -0,0 +1,101 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNetworkResolverPrecedence(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "labeled-ns", Labels: map[string]string{NetworkLabel: "network-ns"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled-ns"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "labeled-node", Labels: map[string]string{NetworkLabel: "network-node"}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled-node"}},
+	)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	namespaces := factory.Core().V1().Namespaces().Informer()
+	nodes := factory.Core().V1().Nodes().Informer()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	factory.Start(stop)
+	cache.WaitForCacheSync(stop, namespaces.HasSynced, nodes.HasSynced)
+
+	resolver := NewNetworkResolver(namespaces, nodes, "default-network")
+
+	cases := []struct {
+		name      string
+		namespace string
+		node      string
+		want      string
+	}{
+		{"namespace label wins", "labeled-ns", "unlabeled-node", "network-ns"},
+		{"falls back to node label", "unlabeled-ns", "labeled-node", "network-node"},
+		{"falls back to mesh default", "unlabeled-ns", "unlabeled-node", "default-network"},
+		{"namespace label beats node label", "labeled-ns", "labeled-node", "network-ns"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolver.Resolve(tc.namespace, tc.node); got != tc.want {
+				t.Errorf("Resolve(%q, %q) = %q, want %q", tc.namespace, tc.node, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNetworkResolverInvalidatesOnLabelChange(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+	)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	namespaces := factory.Core().V1().Namespaces().Informer()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	factory.Start(stop)
+	cache.WaitForCacheSync(stop, namespaces.HasSynced)
+
+	resolver := NewNetworkResolver(namespaces, nil, "default-network")
+
+	if got := resolver.Resolve("ns", "node"); got != "default-network" {
+		t.Fatalf("Resolve() = %q before labeling, want default-network", got)
+	}
+
+	updated := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: map[string]string{NetworkLabel: "network-2"}}}
+	if _, err := client.CoreV1().Namespaces().Update(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update namespace: %v", err)
+	}
+
+	// The informer delivers the update asynchronously; poll until the
+	// resolver's cache has been invalidated and re-populated.
+	deadline := time.Now().Add(2 * time.Second)
+	var got string
+	for time.Now().Before(deadline) {
+		if got = resolver.Resolve("ns", "node"); got == "network-2" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Resolve() = %q after labeling, want network-2", got)
+}
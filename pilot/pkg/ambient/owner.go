@@ -0,0 +1,235 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	wmpb "istio.io/istio/pkg/workloadmetadata/proto"
+)
+
+// ownerKey identifies a single controller object within a namespace.
+type ownerKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// owner is the resolved controlling workload for a pod.
+type owner struct {
+	name string
+	kind wmpb.WorkloadMetadataResource_WorkloadType
+}
+
+// OwnerResolver walks a pod's OwnerReferences chain up to the workload that
+// actually controls it, e.g. ReplicaSet -> Deployment or Job -> CronJob,
+// using informers rather than the pod-template-hash naming heuristic.
+//
+// Resolutions are cached by the pod's immediate controller reference, since
+// many pods typically share the same ReplicaSet or Job, and a cached entry
+// is invalidated whenever the informers observe that controller object
+// itself being added, updated or deleted. A change to the object's own owner
+// (e.g. the CronJob above a Job) does not invalidate it.
+type OwnerResolver struct {
+	replicaSets  cache.SharedIndexInformer
+	jobs         cache.SharedIndexInformer
+	statefulSets cache.SharedIndexInformer
+	daemonSets   cache.SharedIndexInformer
+	cronJobs     cache.SharedIndexInformer
+
+	mu    sync.RWMutex
+	cache map[ownerKey]owner
+}
+
+// NewOwnerResolver builds a resolver backed by the given informers. Any
+// informer may be nil, in which case pods controlled by that kind fall back
+// to the pod-template-hash heuristic.
+func NewOwnerResolver(replicaSets, jobs, statefulSets, daemonSets, cronJobs cache.SharedIndexInformer) *OwnerResolver {
+	r := &OwnerResolver{
+		replicaSets:  replicaSets,
+		jobs:         jobs,
+		statefulSets: statefulSets,
+		daemonSets:   daemonSets,
+		cronJobs:     cronJobs,
+		cache:        make(map[ownerKey]owner),
+	}
+
+	r.registerInvalidation(replicaSets, "ReplicaSet")
+	r.registerInvalidation(jobs, "Job")
+	r.registerInvalidation(statefulSets, "StatefulSet")
+	r.registerInvalidation(daemonSets, "DaemonSet")
+	r.registerInvalidation(cronJobs, "CronJob")
+
+	return r
+}
+
+func (r *OwnerResolver) registerInvalidation(informer cache.SharedIndexInformer, kind string) {
+	if informer == nil {
+		return
+	}
+	handler := func(obj interface{}) {
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		objMeta, err := apimeta.Accessor(obj)
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		delete(r.cache, ownerKey{kind: kind, namespace: objMeta.GetNamespace(), name: objMeta.GetName()})
+		r.mu.Unlock()
+	}
+	// nolint: errcheck
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    handler,
+		UpdateFunc: func(_, obj interface{}) { handler(obj) },
+		DeleteFunc: handler,
+	})
+}
+
+// Resolve returns the name and kind of the Kubernetes workload that controls
+// pod, walking up the controller chain as far as the registered informers
+// allow. It falls back to the pod-template-hash heuristic when the
+// controlling object cannot be found in the informer cache.
+func (r *OwnerResolver) Resolve(pod *v1.Pod) (string, wmpb.WorkloadMetadataResource_WorkloadType) {
+	controllerRef, ok := controllerOf(pod.OwnerReferences)
+	if !ok {
+		return pod.Name, wmpb.WorkloadMetadataResource_KUBERNETES_POD
+	}
+
+	key := ownerKey{kind: controllerRef.Kind, namespace: pod.Namespace, name: controllerRef.Name}
+	if o, ok := r.lookup(key); ok {
+		return o.name, o.kind
+	}
+
+	o, resolved := r.resolveController(pod.Namespace, controllerRef)
+	if !resolved {
+		return heuristicNameAndType(pod, controllerRef)
+	}
+
+	r.mu.Lock()
+	r.cache[key] = o
+	r.mu.Unlock()
+	return o.name, o.kind
+}
+
+func (r *OwnerResolver) lookup(key ownerKey) (owner, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.cache[key]
+	return o, ok
+}
+
+func (r *OwnerResolver) resolveController(namespace string, ref metav1.OwnerReference) (owner, bool) {
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs, ok := getByKey(r.replicaSets, namespace, ref.Name)
+		if !ok {
+			return owner{}, false
+		}
+		if parent, ok := controllerOf(rs.GetOwnerReferences()); ok && parent.Kind == "Deployment" {
+			return owner{name: parent.Name, kind: wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT}, true
+		}
+		// Standalone ReplicaSet with no Deployment above it: report it as
+		// what it actually is rather than fabricating a Deployment owner.
+		return owner{name: rs.GetName(), kind: wmpb.WorkloadMetadataResource_KUBERNETES_REPLICASET}, true
+	case "Job":
+		job, ok := getByKey(r.jobs, namespace, ref.Name)
+		if !ok {
+			return owner{}, false
+		}
+		if parent, ok := controllerOf(job.GetOwnerReferences()); ok && parent.Kind == "CronJob" {
+			return owner{name: parent.Name, kind: wmpb.WorkloadMetadataResource_KUBERNETES_CRONJOB}, true
+		}
+		return owner{name: job.GetName(), kind: wmpb.WorkloadMetadataResource_KUBERNETES_JOB}, true
+	case "StatefulSet":
+		if _, ok := getByKey(r.statefulSets, namespace, ref.Name); !ok {
+			return owner{}, false
+		}
+		return owner{name: ref.Name, kind: wmpb.WorkloadMetadataResource_KUBERNETES_STATEFULSET}, true
+	case "DaemonSet":
+		if _, ok := getByKey(r.daemonSets, namespace, ref.Name); !ok {
+			return owner{}, false
+		}
+		return owner{name: ref.Name, kind: wmpb.WorkloadMetadataResource_KUBERNETES_DAEMONSET}, true
+	case "CronJob":
+		if _, ok := getByKey(r.cronJobs, namespace, ref.Name); !ok {
+			return owner{}, false
+		}
+		return owner{name: ref.Name, kind: wmpb.WorkloadMetadataResource_KUBERNETES_CRONJOB}, true
+	default:
+		return owner{}, false
+	}
+}
+
+// getByKey looks up namespace/name in informer's indexer, returning the
+// object and whether it was present. A nil informer (kind not wired up) or a
+// miss both report false so callers fall back to the naming heuristic.
+func getByKey(informer cache.SharedIndexInformer, namespace, name string) (metav1.Object, bool) {
+	if informer == nil {
+		return nil, false
+	}
+	obj, exists, err := informer.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	objMeta, err := apimeta.Accessor(obj)
+	if err != nil {
+		return nil, false
+	}
+	return objMeta, true
+}
+
+func controllerOf(refs []metav1.OwnerReference) (metav1.OwnerReference, bool) {
+	for _, ref := range refs {
+		if ref.Controller != nil && *ref.Controller {
+			return ref, true
+		}
+	}
+	return metav1.OwnerReference{}, false
+}
+
+// heuristicNameAndType is the pre-informer fallback: it trims the
+// pod-template-hash suffix off a ReplicaSet name to recover the owning
+// Deployment's name, and otherwise just reports the immediate controller.
+// It's used only when the controlling object can't be fetched, e.g. it was
+// already deleted or the informer hasn't synced yet.
+func heuristicNameAndType(pod *v1.Pod, controllerRef metav1.OwnerReference) (string, wmpb.WorkloadMetadataResource_WorkloadType) {
+	if hash := pod.Labels["pod-template-hash"]; controllerRef.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(controllerRef.Name, hash) {
+		name := strings.TrimSuffix(controllerRef.Name, "-"+pod.Labels["pod-template-hash"])
+		return name, wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT
+	}
+
+	switch controllerRef.Kind {
+	case "ReplicaSet":
+		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_REPLICASET
+	case "Job":
+		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_JOB
+	case "CronJob":
+		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_CRONJOB
+	case "StatefulSet":
+		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_STATEFULSET
+	case "DaemonSet":
+		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_DAEMONSET
+	default:
+		return pod.Name, wmpb.WorkloadMetadataResource_KUBERNETES_POD
+	}
+}
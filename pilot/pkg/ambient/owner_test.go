@@ -0,0 +1,159 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	wmpb "istio.io/istio/pkg/workloadmetadata/proto"
+)
+
+func controllerRef(kind, name string) metav1.OwnerReference {
+	t := true
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: &t}
+}
+
+func newTestResolver(t *testing.T, objects ...runtime.Object) *OwnerResolver {
+	t.Helper()
+	client := fake.NewSimpleClientset(objects...)
+	factory := informers.NewSharedInformerFactory(client, 0)
+
+	rs := factory.Apps().V1().ReplicaSets().Informer()
+	jobs := factory.Batch().V1().Jobs().Informer()
+	sts := factory.Apps().V1().StatefulSets().Informer()
+	ds := factory.Apps().V1().DaemonSets().Informer()
+	cj := factory.Batch().V1().CronJobs().Informer()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	factory.Start(stop)
+	cache.WaitForCacheSync(stop, rs.HasSynced, jobs.HasSynced, sts.HasSynced, ds.HasSynced, cj.HasSynced)
+
+	return NewOwnerResolver(rs, jobs, sts, ds, cj)
+}
+
+func TestOwnerResolverReplicaSetToDeployment(t *testing.T) {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "reviews-v1-6c77fdf68b",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("Deployment", "reviews-v1")},
+		},
+	}
+	resolver := newTestResolver(t, rs)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "reviews-v1-6c77fdf68b-abcde",
+			Namespace:       "default",
+			GenerateName:    "reviews-v1-6c77fdf68b-",
+			Labels:          map[string]string{"pod-template-hash": "6c77fdf68b"},
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "reviews-v1-6c77fdf68b")},
+		},
+	}
+
+	name, kind := resolver.Resolve(pod)
+	if name != "reviews-v1" || kind != wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT {
+		t.Fatalf("got (%s, %v), want (reviews-v1, KUBERNETES_DEPLOYMENT)", name, kind)
+	}
+}
+
+func TestOwnerResolverStandaloneReplicaSet(t *testing.T) {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "standalone-rs",
+			Namespace: "default",
+		},
+	}
+	resolver := newTestResolver(t, rs)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "standalone-rs-abcde",
+			Namespace:       "default",
+			GenerateName:    "standalone-rs-",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "standalone-rs")},
+		},
+	}
+
+	name, kind := resolver.Resolve(pod)
+	if name != "standalone-rs" || kind != wmpb.WorkloadMetadataResource_KUBERNETES_REPLICASET {
+		t.Fatalf("got (%s, %v), want (standalone-rs, KUBERNETES_REPLICASET)", name, kind)
+	}
+}
+
+func TestOwnerResolverJobToCronJob(t *testing.T) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cleanup-27891234",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("CronJob", "cleanup")},
+		},
+	}
+	resolver := newTestResolver(t, job)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cleanup-27891234-xyz",
+			Namespace:       "default",
+			GenerateName:    "cleanup-27891234-",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("Job", "cleanup-27891234")},
+		},
+	}
+
+	name, kind := resolver.Resolve(pod)
+	if name != "cleanup" || kind != wmpb.WorkloadMetadataResource_KUBERNETES_CRONJOB {
+		t.Fatalf("got (%s, %v), want (cleanup, KUBERNETES_CRONJOB)", name, kind)
+	}
+}
+
+func TestOwnerResolverFallsBackToHeuristic(t *testing.T) {
+	resolver := newTestResolver(t)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "reviews-v1-6c77fdf68b-abcde",
+			Namespace:       "default",
+			GenerateName:    "reviews-v1-6c77fdf68b-",
+			Labels:          map[string]string{"pod-template-hash": "6c77fdf68b"},
+			OwnerReferences: []metav1.OwnerReference{controllerRef("ReplicaSet", "reviews-v1-6c77fdf68b")},
+		},
+	}
+
+	name, kind := resolver.Resolve(pod)
+	if name != "reviews-v1" || kind != wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT {
+		t.Fatalf("got (%s, %v), want (reviews-v1, KUBERNETES_DEPLOYMENT)", name, kind)
+	}
+}
+
+func TestOwnerResolverNoController(t *testing.T) {
+	resolver := newTestResolver(t)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}}
+
+	name, kind := resolver.Resolve(pod)
+	if name != "standalone" || kind != wmpb.WorkloadMetadataResource_KUBERNETES_POD {
+		t.Fatalf("got (%s, %v), want (standalone, KUBERNETES_POD)", name, kind)
+	}
+}
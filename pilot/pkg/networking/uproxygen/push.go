@@ -0,0 +1,41 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxygen
+
+import (
+	"istio.io/istio/pilot/pkg/ambient"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/model/kind"
+)
+
+// RegisterPushTrigger wires workloads' node-change notifications into push,
+// translating each changed node into the kind.Address ConfigKey that
+// GenerateDeltas's nodeUpdated check filters on. Without this, an ambient
+// pod add/remove/mutation would only ever reach connected uproxies on
+// istiod's periodic full-resync push, never through the per-node delta path
+// this generator otherwise supports.
+//
+// push is DiscoveryServer.ConfigUpdate in a real istiod; the ambient pod
+// controller that owns workloads' UpdateSnapshot calls should call this once
+// at startup, alongside constructing the cache itself.
+func RegisterPushTrigger(workloads ambient.Cache, push func(*model.PushRequest)) {
+	workloads.OnNodesChanged(func(nodes map[string]struct{}) {
+		updated := make(map[model.ConfigKey]struct{}, len(nodes))
+		for node := range nodes {
+			updated[model.ConfigKey{Kind: kind.Address, Name: node}] = struct{}{}
+		}
+		push(&model.PushRequest{ConfigsUpdated: updated})
+	})
+}
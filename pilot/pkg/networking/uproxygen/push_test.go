@@ -0,0 +1,47 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxygen
+
+import (
+	"testing"
+
+	"istio.io/istio/pilot/pkg/ambient"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/model/kind"
+)
+
+func TestRegisterPushTriggerTranslatesNodeChanges(t *testing.T) {
+	cache := ambient.NewCache(
+		ambient.NewOwnerResolver(nil, nil, nil, nil, nil),
+		ambient.NewNetworkResolver(nil, nil, ""),
+	)
+
+	var pushed []*model.PushRequest
+	RegisterPushTrigger(cache, func(req *model.PushRequest) { pushed = append(pushed, req) })
+
+	cache.UpdateSnapshot(&ambient.Snapshot{
+		Workloads: ambient.NewWorkloadIndex(map[string][]*ambient.PodInfo{
+			benchNode: nPods(1),
+		}),
+	})
+
+	if len(pushed) != 1 {
+		t.Fatalf("expected 1 push, got %d", len(pushed))
+	}
+	key := model.ConfigKey{Kind: kind.Address, Name: benchNode}
+	if _, ok := pushed[0].ConfigsUpdated[key]; !ok {
+		t.Fatalf("expected ConfigsUpdated to contain %v, got %v", key, pushed[0].ConfigsUpdated)
+	}
+}
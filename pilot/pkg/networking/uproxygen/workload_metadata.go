@@ -15,16 +15,16 @@
 package uproxygen
 
 import (
-	"strings"
+	"sync"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"istio.io/istio/pilot/pkg/ambient"
 	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/model/kind"
 	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/cluster"
 	"istio.io/istio/pkg/kube/labels"
 	wmpb "istio.io/istio/pkg/workloadmetadata/proto"
 )
@@ -39,49 +39,49 @@ const (
 // workload instances (Kubernetes pods) running on a Kubernetes node.
 type WorkloadMetadataGenerator struct {
 	Workloads ambient.Cache
+
+	// TrustDomain and ClusterID are mesh-wide, so they're injected once
+	// rather than resolved per pod; they complete the SPIFFE identity
+	// (trust_domain + service_account) and cluster scoping of each resource.
+	TrustDomain string
+	ClusterID   cluster.ID
+
+	mu sync.Mutex
+	// sentVersions records, per proxy ID, the Kubernetes ResourceVersion of
+	// each workload UID as of the last time GenerateDeltas generated that
+	// proxy's resource for it, so an in-place pod mutation (same UID, new
+	// ResourceVersion) is recognized as a change rather than skipped as
+	// already pushed. A single WorkloadMetadataGenerator instance serves
+	// every connected uproxy, so this must be scoped per proxy rather than
+	// shared across all of them: otherwise a reconcile driven by one proxy's
+	// push could mask a change another proxy never actually received.
+	//
+	// Known limitation: entries are only ever refreshed, never removed, on a
+	// proxy's own disconnect, since this package has no hook into xDS
+	// connection teardown. A long-lived istiod will accumulate one entry per
+	// distinct proxy ID ever seen rather than per currently-connected proxy;
+	// wiring eviction to the real connection-close event is left to whatever
+	// owns that lifecycle.
+	sentVersions map[string]map[string]string
 }
 
-var _ model.XdsResourceGenerator = &WorkloadMetadataGenerator{}
+var (
+	_ model.XdsResourceGenerator      = &WorkloadMetadataGenerator{}
+	_ model.XdsDeltaResourceGenerator = &WorkloadMetadataGenerator{}
+)
 
 func (g *WorkloadMetadataGenerator) Generate(proxy *model.Proxy, w *model.WatchedResource, req *model.PushRequest) (
 	model.Resources, model.XdsLogDetails, error,
 ) {
-	// TODO: check whether or not a push is required?
-	// Need to figure out how to push to a node based on deltas in pods on a node
-
 	// this is the name of the Kubernetes node on which the proxy requesting this
 	// configuration lives.
 	proxyKubernetesNodeName := proxy.Metadata.NodeName
 
-	var workloads []*wmpb.WorkloadMetadataResource
+	workloads := make(map[string]*wmpb.WorkloadMetadataResource)
 
 	for _, pod := range g.Workloads.SidecarlessWorkloads().Workloads.ByNode[proxyKubernetesNodeName] {
-		// TODO: this is cheating. we need a way to get the owing workload name
-		// in a way that isn't a shortcut.
-		name, workloadType := workloadNameAndType(pod.Pod)
-		cs, cr := labels.CanonicalService(pod.Labels, name)
-
-		ips := []string{}
-		for _, pip := range pod.Status.PodIPs {
-			ips = append(ips, pip.IP)
-		}
-
-		containers := []string{}
-		for _, c := range pod.Spec.Containers {
-			containers = append(containers, c.Name)
-		}
-
-		workloads = append(workloads,
-			&wmpb.WorkloadMetadataResource{
-				IpAddresses:       ips,
-				InstanceName:      pod.Name,
-				NamespaceName:     pod.Namespace,
-				Containers:        containers,
-				WorkloadName:      name,
-				WorkloadType:      workloadType,
-				CanonicalName:     cs,
-				CanonicalRevision: cr,
-			})
+		uid, resource := g.workloadResource(pod)
+		workloads[uid] = resource
 	}
 
 	wmd := &wmpb.WorkloadMetadataResources{
@@ -98,42 +98,118 @@ func (g *WorkloadMetadataGenerator) Generate(proxy *model.Proxy, w *model.Watche
 	return resources, model.DefaultXdsLogDetails, nil
 }
 
-// total hack
-func workloadNameAndType(pod *v1.Pod) (string, wmpb.WorkloadMetadataResource_WorkloadType) {
-	if len(pod.GenerateName) == 0 {
-		return pod.Name, wmpb.WorkloadMetadataResource_KUBERNETES_POD
+// GenerateDeltas reports only the workload metadata that changed for
+// proxy's node since the last push, instead of the Generate's full re-push
+// of every pod on the node. When req isn't a full push and none of the
+// updated config keys touch proxy's node, the push is skipped entirely:
+// ambient.Cache's node->UID index lets us answer that without walking any
+// pods at all.
+func (g *WorkloadMetadataGenerator) GenerateDeltas(proxy *model.Proxy, req *model.PushRequest, w *model.WatchedResource) (
+	model.Resources, model.DeletedResources, model.XdsLogDetails, bool, error,
+) {
+	nodeName := proxy.Metadata.NodeName
+
+	if !req.Full && !nodeUpdated(nodeName, req.ConfigsUpdated) {
+		return nil, nil, model.DefaultXdsLogDetails, true, nil
+	}
+
+	pods := g.Workloads.SidecarlessWorkloads().Workloads.ByNode[nodeName]
+	current := make(map[string]*ambient.PodInfo, len(pods))
+	for _, pod := range pods {
+		current[string(pod.UID)] = pod
 	}
 
-	// if the pod name was generated (or is scheduled for generation), we can begin an investigation into the controlling reference for the pod.
-	var controllerRef metav1.OwnerReference
-	controllerFound := false
-	for _, ref := range pod.GetOwnerReferences() {
-		if *ref.Controller {
-			controllerRef = ref
-			controllerFound = true
-			break
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.sentVersions == nil {
+		g.sentVersions = make(map[string]map[string]string)
+	}
+	sent := g.sentVersions[proxy.ID]
+	if sent == nil || req.Full {
+		// A full push is also our chance to reconcile: rebuild this proxy's
+		// record from scratch, scoped to only the pods it's about to be
+		// told about (its own node's current set), so a UID it's no longer
+		// watching can't linger in this process-lifetime map forever.
+		sent = make(map[string]string, len(current))
+	}
+
+	var resources model.Resources
+	for uid, pod := range current {
+		lastVersion, known := sent[uid]
+		unchanged := known && lastVersion == pod.ResourceVersion
+
+		if !req.Full && w.ResourceNames.Contains(uid) && unchanged {
+			// Already pushed to this proxy and the pod's ResourceVersion
+			// hasn't moved since, so skip re-sending it.
+			continue
 		}
+		_, resource := g.workloadResource(pod)
+		resources = append(resources, &discovery.Resource{
+			Name:     uid,
+			Resource: util.MessageToAny(resource),
+		})
+		sent[uid] = pod.ResourceVersion
 	}
 
-	if !controllerFound {
-		return pod.Name, wmpb.WorkloadMetadataResource_KUBERNETES_POD
+	var removed model.DeletedResources
+	for uid := range w.ResourceNames {
+		if _, ok := current[uid]; !ok {
+			removed = append(removed, uid)
+			delete(sent, uid)
+		}
 	}
+	g.sentVersions[proxy.ID] = sent
 
-	// heuristic for deployment detection
-	if controllerRef.Kind == "ReplicaSet" && strings.HasSuffix(controllerRef.Name, pod.Labels["pod-template-hash"]) {
-		name := strings.TrimSuffix(controllerRef.Name, "-"+pod.Labels["pod-template-hash"])
-		return name, wmpb.WorkloadMetadataResource_KUBERNETES_DEPLOYMENT
+	return resources, removed, model.DefaultXdsLogDetails, true, nil
+}
+
+// workloadResource builds the WorkloadMetadataResource for a single pod,
+// keyed by its Kubernetes UID. It's shared by the full and delta generators
+// so the two can never drift on what a pod's resource looks like.
+func (g *WorkloadMetadataGenerator) workloadResource(pod *ambient.PodInfo) (string, *wmpb.WorkloadMetadataResource) {
+	name, workloadType := g.Workloads.Owners().Resolve(pod.Pod)
+	cs, cr := labels.CanonicalService(pod.Labels, name)
+
+	ips := []string{}
+	for _, pip := range pod.Status.PodIPs {
+		ips = append(ips, pip.IP)
 	}
 
-	if controllerRef.Kind == "Job" {
-		// figure out how to go from Job -> CronJob
-		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_JOB
+	containers := []string{}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, c.Name)
 	}
 
-	if controllerRef.Kind == "CronJob" {
-		// figure out how to go from Job -> CronJob
-		return controllerRef.Name, wmpb.WorkloadMetadataResource_KUBERNETES_CRONJOB
+	uid := string(pod.UID)
+	return uid, &wmpb.WorkloadMetadataResource{
+		IpAddresses:       ips,
+		InstanceName:      pod.Name,
+		NamespaceName:     pod.Namespace,
+		Containers:        containers,
+		WorkloadName:      name,
+		WorkloadType:      workloadType,
+		CanonicalName:     cs,
+		CanonicalRevision: cr,
+		Uid:               uid,
+		NetworkName:       g.Workloads.Networks().Resolve(pod.Namespace, pod.Spec.NodeName),
+		TrustDomain:       g.TrustDomain,
+		ServiceAccount:    pod.Spec.ServiceAccountName,
+		ClusterId:         string(g.ClusterID),
+		NodeName:          pod.Spec.NodeName,
+		Labels:            pod.Labels,
+		Hostname:          pod.Spec.Hostname,
 	}
+}
 
-	return pod.Name, wmpb.WorkloadMetadataResource_KUBERNETES_POD
+// nodeUpdated reports whether any of the updated config keys correspond to
+// ambient pod changes on node. RegisterPushTrigger (push.go) publishes one
+// kind.Address key per affected node (Name holding the node name) so the
+// push queue - and this generator - can filter without per-pod detail.
+func nodeUpdated(node string, updated map[model.ConfigKey]struct{}) bool {
+	for key := range updated {
+		if key.Kind == kind.Address && key.Name == node {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,304 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uproxygen
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pilot/pkg/ambient"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/model/kind"
+	"istio.io/istio/pkg/util/sets"
+)
+
+const benchNode = "node-0"
+
+func nPods(n int) []*ambient.PodInfo {
+	pods := make([]*ambient.PodInfo, n)
+	for i := 0; i < n; i++ {
+		pods[i] = &ambient.PodInfo{Pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("pod-%d", i),
+				Namespace:       "default",
+				UID:             types.UID(fmt.Sprintf("uid-%d", i)),
+				ResourceVersion: "1",
+			},
+			Spec: v1.PodSpec{NodeName: benchNode},
+		}}
+	}
+	return pods
+}
+
+func newGenerator(pods []*ambient.PodInfo) *WorkloadMetadataGenerator {
+	cache := ambient.NewCache(
+		ambient.NewOwnerResolver(nil, nil, nil, nil, nil),
+		ambient.NewNetworkResolver(nil, nil, ""),
+	)
+	cache.UpdateSnapshot(&ambient.Snapshot{
+		Workloads: ambient.NewWorkloadIndex(map[string][]*ambient.PodInfo{benchNode: pods}),
+	})
+	return &WorkloadMetadataGenerator{Workloads: cache}
+}
+
+func testProxyAndWatched(proxyID string, resourceNames sets.String) (*model.Proxy, *model.WatchedResource) {
+	return &model.Proxy{ID: proxyID, Metadata: &model.NodeMetadata{NodeName: benchNode}},
+		&model.WatchedResource{ResourceNames: resourceNames}
+}
+
+func TestWorkloadResourcePopulatesFields(t *testing.T) {
+	pod := &ambient.PodInfo{Pod: &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews-v1-abcde",
+			Namespace: "default",
+			UID:       types.UID("pod-uid"),
+			Labels:    map[string]string{"app": "reviews"},
+		},
+		Spec: v1.PodSpec{
+			NodeName:           benchNode,
+			ServiceAccountName: "reviews-sa",
+			Hostname:           "reviews-0",
+			Containers:         []v1.Container{{Name: "reviews"}, {Name: "istio-proxy"}},
+		},
+		Status: v1.PodStatus{PodIPs: []v1.PodIP{{IP: "10.0.0.1"}}},
+	}}
+
+	cache := ambient.NewCache(
+		ambient.NewOwnerResolver(nil, nil, nil, nil, nil),
+		ambient.NewNetworkResolver(nil, nil, "default-network"),
+	)
+	cache.UpdateSnapshot(&ambient.Snapshot{
+		Workloads: ambient.NewWorkloadIndex(map[string][]*ambient.PodInfo{benchNode: {pod}}),
+	})
+	g := &WorkloadMetadataGenerator{Workloads: cache, TrustDomain: "cluster.local", ClusterID: "Kubernetes"}
+
+	uid, resource := g.workloadResource(pod)
+	if uid != "pod-uid" || resource.Uid != "pod-uid" {
+		t.Errorf("Uid = %q, want pod-uid", resource.Uid)
+	}
+	if resource.ServiceAccount != "reviews-sa" {
+		t.Errorf("ServiceAccount = %q, want reviews-sa", resource.ServiceAccount)
+	}
+	if resource.TrustDomain != "cluster.local" {
+		t.Errorf("TrustDomain = %q, want cluster.local", resource.TrustDomain)
+	}
+	if resource.ClusterId != "Kubernetes" {
+		t.Errorf("ClusterId = %q, want Kubernetes", resource.ClusterId)
+	}
+	if resource.NodeName != benchNode {
+		t.Errorf("NodeName = %q, want %q", resource.NodeName, benchNode)
+	}
+	if resource.Hostname != "reviews-0" {
+		t.Errorf("Hostname = %q, want reviews-0", resource.Hostname)
+	}
+	if resource.Labels["app"] != "reviews" {
+		t.Errorf("Labels[app] = %q, want reviews", resource.Labels["app"])
+	}
+	if resource.NetworkName != "default-network" {
+		t.Errorf("NetworkName = %q, want default-network", resource.NetworkName)
+	}
+	if len(resource.IpAddresses) != 1 || resource.IpAddresses[0] != "10.0.0.1" {
+		t.Errorf("IpAddresses = %v, want [10.0.0.1]", resource.IpAddresses)
+	}
+	if len(resource.Containers) != 2 || resource.Containers[0] != "reviews" || resource.Containers[1] != "istio-proxy" {
+		t.Errorf("Containers = %v, want [reviews istio-proxy]", resource.Containers)
+	}
+}
+
+func TestGenerateDeltasSkipsUnrelatedNodes(t *testing.T) {
+	g := newGenerator(nPods(3))
+	proxy, w := testProxyAndWatched("proxy-a", sets.New[string]())
+
+	req := &model.PushRequest{ConfigsUpdated: map[model.ConfigKey]struct{}{
+		{Kind: kind.Address, Name: "some-other-node"}: {},
+	}}
+
+	resources, removed, _, used, err := g.GenerateDeltas(proxy, req, w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !used {
+		t.Fatal("expected delta generation to be used")
+	}
+	if len(resources) != 0 || len(removed) != 0 {
+		t.Fatalf("expected no push for an unrelated node, got %d resources, %d removed", len(resources), len(removed))
+	}
+}
+
+func TestGenerateDeltasOnlySendsChangedPods(t *testing.T) {
+	pods := nPods(3)
+	g := newGenerator(pods)
+	// Simulate uid-0 and uid-1 already having been generated for this proxy,
+	// at their current ResourceVersion, by an earlier push.
+	g.sentVersions = map[string]map[string]string{"proxy-a": {"uid-0": "1", "uid-1": "1"}}
+	proxy, w := testProxyAndWatched("proxy-a", sets.New("uid-0", "uid-1"))
+
+	req := &model.PushRequest{ConfigsUpdated: map[model.ConfigKey]struct{}{
+		{Kind: kind.Address, Name: benchNode}: {},
+	}}
+
+	resources, removed, _, used, err := g.GenerateDeltas(proxy, req, w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !used {
+		t.Fatal("expected delta generation to be used")
+	}
+	if len(removed) != 0 {
+		t.Fatalf("expected nothing removed, got %v", removed)
+	}
+	if len(resources) != 1 || resources[0].Name != "uid-2" {
+		t.Fatalf("expected only the new pod uid-2 to be sent, got %v", resources)
+	}
+}
+
+// TestGenerateDeltasResendsMutatedPods covers a pod that was already pushed
+// to the proxy (its UID is in w.ResourceNames) but has since been mutated in
+// place, e.g. relabeled: its ResourceVersion no longer matches what was last
+// generated for it, so it must be resent rather than skipped.
+func TestGenerateDeltasResendsMutatedPods(t *testing.T) {
+	pods := nPods(3)
+	g := newGenerator(pods)
+	g.sentVersions = map[string]map[string]string{"proxy-a": {"uid-0": "1", "uid-1": "1"}}
+	// pod uid-1 was mutated after it was last generated.
+	pods[1].ResourceVersion = "2"
+
+	proxy, w := testProxyAndWatched("proxy-a", sets.New("uid-0", "uid-1"))
+	req := &model.PushRequest{ConfigsUpdated: map[model.ConfigKey]struct{}{
+		{Kind: kind.Address, Name: benchNode}: {},
+	}}
+
+	resources, _, _, used, err := g.GenerateDeltas(proxy, req, w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !used {
+		t.Fatal("expected delta generation to be used")
+	}
+
+	names := sets.New[string]()
+	for _, r := range resources {
+		names.Insert(r.Name)
+	}
+	if !names.Contains("uid-1") {
+		t.Fatalf("expected mutated pod uid-1 to be resent, got %v", names)
+	}
+	if names.Contains("uid-0") {
+		t.Fatalf("expected unchanged pod uid-0 to be skipped, got %v", names)
+	}
+}
+
+// TestGenerateDeltasTracksVersionsPerProxy covers two proxies served by the
+// same generator instance: a full push that reconciles proxy A's record
+// must not mask a pending change from proxy B, which never received it.
+func TestGenerateDeltasTracksVersionsPerProxy(t *testing.T) {
+	pods := nPods(1)
+	g := newGenerator(pods)
+
+	proxyA, wA := testProxyAndWatched("proxy-a", sets.New[string]())
+	proxyB, wB := testProxyAndWatched("proxy-b", sets.New[string]())
+	req := &model.PushRequest{ConfigsUpdated: map[model.ConfigKey]struct{}{
+		{Kind: kind.Address, Name: benchNode}: {},
+	}}
+
+	// Both proxies receive the pod at ResourceVersion "1" and ack it.
+	if _, _, _, _, err := g.GenerateDeltas(proxyA, req, wA); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, _, _, err := g.GenerateDeltas(proxyB, req, wB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wA.ResourceNames = sets.New("uid-0")
+	wB.ResourceNames = sets.New("uid-0")
+
+	// The pod is mutated, then proxy A alone gets a full push that
+	// reconciles its own record to the new version.
+	pods[0].ResourceVersion = "2"
+	fullReq := &model.PushRequest{Full: true}
+	if _, _, _, _, err := g.GenerateDeltas(proxyA, fullReq, wA); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Proxy B still holds the stale copy and hasn't been pushed since the
+	// mutation: it must still receive the update, not have it masked by
+	// proxy A's reconcile.
+	resources, _, _, _, err := g.GenerateDeltas(proxyB, req, wB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 || resources[0].Name != "uid-0" {
+		t.Fatalf("expected proxy B to receive the mutated pod, got %v", resources)
+	}
+}
+
+func TestGenerateDeltasReportsRemovedPods(t *testing.T) {
+	g := newGenerator(nPods(1))
+	proxy, w := testProxyAndWatched("proxy-a", sets.New("uid-0", "uid-stale"))
+
+	req := &model.PushRequest{Full: true}
+
+	_, removed, _, _, err := g.GenerateDeltas(proxy, req, w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "uid-stale" {
+		t.Fatalf("expected uid-stale to be reported removed, got %v", removed)
+	}
+}
+
+// BenchmarkGenerateFull measures the cost of Generate's full pod re-push for
+// a node with 1k ambient pods.
+func BenchmarkGenerateFull(b *testing.B) {
+	g := newGenerator(nPods(1000))
+	proxy, w := testProxyAndWatched("proxy-a", sets.New[string]())
+	req := &model.PushRequest{Full: true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := g.Generate(proxy, w, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGenerateDeltasSinglePodChurn measures the cost of a delta push
+// when only one of 1k pods on the node actually changed, which is the
+// steady-state churn pattern this generator is optimized for.
+func BenchmarkGenerateDeltasSinglePodChurn(b *testing.B) {
+	pods := nPods(1000)
+	g := newGenerator(pods)
+
+	acked := sets.New[string]()
+	for _, pod := range pods[1:] {
+		acked.Insert(string(pod.UID))
+	}
+	proxy, w := testProxyAndWatched("proxy-a", acked)
+	req := &model.PushRequest{ConfigsUpdated: map[model.ConfigKey]struct{}{
+		{Kind: kind.Address, Name: benchNode}: {},
+	}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, _, err := g.GenerateDeltas(proxy, req, w); err != nil {
+			b.Fatal(err)
+		}
+	}
+}